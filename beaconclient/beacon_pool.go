@@ -0,0 +1,164 @@
+package beaconclient
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Role identifies a family of beacon-API operations that a given beacon instance
+// can be dedicated to (or excluded from). This lets operators run a "hot" beacon
+// node reserved for publishing blocks while spreading read traffic across others.
+type Role string
+
+const (
+	RolePublish Role = "publish" // PublishBlock / PublishBlockWithBlobs
+	RoleDuties  Role = "duties"  // GetProposerDuties, GetRandao, GetWithdrawals
+	RoleEvents  Role = "events"  // head / payload-attributes SSE subscriptions
+	RoleReads   Role = "reads"   // GetStateValidators, GetBlock, GetBlobSidecars, GetGenesis, GetSpec, GetForkSchedule
+)
+
+// ewmaAlpha controls how quickly the rolling score reacts to new samples; a higher
+// alpha weighs recent requests more heavily.
+const ewmaAlpha = 0.2
+
+// maxHeadSlotLag is how many slots an instance may trail the pool median head slot
+// before HealthLoop demotes it.
+const maxHeadSlotLag = 3
+
+// instanceScore tracks a decayed moving average of round-trip-time and error rate
+// for a single (instance, role) pair.
+type instanceScore struct {
+	mu         sync.Mutex
+	rttEWMA    float64 // milliseconds
+	errorEWMA  float64 // 0..1
+	demoted    bool
+	sampleSeen bool
+}
+
+func (s *instanceScore) record(rtt time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	errSample := 0.0
+	if err != nil {
+		errSample = 1.0
+	}
+
+	if !s.sampleSeen {
+		s.rttEWMA = float64(rtt.Milliseconds())
+		s.errorEWMA = errSample
+		s.sampleSeen = true
+		return
+	}
+
+	s.rttEWMA = ewmaAlpha*float64(rtt.Milliseconds()) + (1-ewmaAlpha)*s.rttEWMA
+	s.errorEWMA = ewmaAlpha*errSample + (1-ewmaAlpha)*s.errorEWMA
+}
+
+// value returns a lower-is-better score. Error rate dominates the ranking so a
+// flaky-but-fast node doesn't outrank a reliable-but-slower one.
+func (s *instanceScore) value() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.demoted {
+		return math.Inf(1)
+	}
+
+	return s.errorEWMA*10000 + s.rttEWMA
+}
+
+func (s *instanceScore) setDemoted(demoted bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.demoted = demoted
+}
+
+// beaconPool tracks per-(instance, role) health scores for the beacon instances
+// managed by a MultiBeaconClient.
+type beaconPool struct {
+	mu     sync.Mutex
+	scores map[string]map[Role]*instanceScore // keyed by instance URI
+}
+
+func newBeaconPool() *beaconPool {
+	return &beaconPool{
+		scores: make(map[string]map[Role]*instanceScore),
+	}
+}
+
+func (p *beaconPool) scoreFor(uri string, role Role) *instanceScore {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	byRole, ok := p.scores[uri]
+	if !ok {
+		byRole = make(map[Role]*instanceScore)
+		p.scores[uri] = byRole
+	}
+
+	s, ok := byRole[role]
+	if !ok {
+		s = &instanceScore{}
+		byRole[role] = s
+	}
+
+	return s
+}
+
+// hasRole returns true if the instance declares it serves the given role, or if it
+// declares no roles at all (meaning it serves every role - the default for a
+// single-beacon setup).
+func hasRole(instance IBeaconInstance, role Role) bool {
+	roles := instance.Roles()
+	if len(roles) == 0 {
+		return true
+	}
+
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// instancesFor returns the beacon instances eligible for the given role, ordered
+// best-first by their decayed (error rate, latency) score for that role.
+func (c *MultiBeaconClient) instancesFor(role Role) []IBeaconInstance {
+	eligible := make([]IBeaconInstance, 0, len(c.beaconInstances))
+	for _, instance := range c.beaconInstances {
+		if hasRole(instance, role) {
+			eligible = append(eligible, instance)
+		}
+	}
+
+	// Fall back to every instance if none declared this role, rather than stalling
+	// the relay on a misconfiguration.
+	if len(eligible) == 0 {
+		eligible = append(eligible, c.beaconInstances...)
+	}
+
+	scores := make([]float64, len(eligible))
+	for i, instance := range eligible {
+		scores[i] = c.pool.scoreFor(instance.GetURI(), role).value()
+	}
+
+	// Simple insertion sort: pools are small (a handful of beacon nodes).
+	for i := 1; i < len(eligible); i++ {
+		for j := i; j > 0 && scores[j] < scores[j-1]; j-- {
+			eligible[j], eligible[j-1] = eligible[j-1], eligible[j]
+			scores[j], scores[j-1] = scores[j-1], scores[j]
+		}
+	}
+
+	return eligible
+}
+
+// recordResult updates the rolling health score for an (instance, role) pair. Call
+// this after every beacon-API round-trip so instancesFor reflects current health.
+func (c *MultiBeaconClient) recordResult(uri string, role Role, rtt time.Duration, err error) {
+	c.pool.scoreFor(uri, role).record(rtt, err)
+}
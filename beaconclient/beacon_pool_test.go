@@ -0,0 +1,63 @@
+package beaconclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestInstancesFor_OrdersByRecordedScore(t *testing.T) {
+	slow := &fakeHeadEventsInstance{uri: "fake://slow"}
+	fast := &fakeHeadEventsInstance{uri: "fake://fast"}
+
+	c := NewMultiBeaconClient(logrus.NewEntry(logrus.New()), []IBeaconInstance{slow, fast})
+
+	c.recordResult(slow.GetURI(), RoleReads, 200*time.Millisecond, nil)
+	c.recordResult(fast.GetURI(), RoleReads, 10*time.Millisecond, nil)
+
+	got := c.instancesFor(RoleReads)
+	if len(got) != 2 || got[0].GetURI() != "fake://fast" || got[1].GetURI() != "fake://slow" {
+		t.Fatalf("expected [fast, slow], got %v, %v", got[0].GetURI(), got[1].GetURI())
+	}
+}
+
+func TestInstancesFor_ErrorRateDominatesLatency(t *testing.T) {
+	flakyButFast := &fakeHeadEventsInstance{uri: "fake://flaky"}
+	reliableButSlow := &fakeHeadEventsInstance{uri: "fake://reliable"}
+
+	c := NewMultiBeaconClient(logrus.NewEntry(logrus.New()), []IBeaconInstance{flakyButFast, reliableButSlow})
+
+	c.recordResult(flakyButFast.GetURI(), RoleReads, 1*time.Millisecond, errors.New("boom"))
+	c.recordResult(reliableButSlow.GetURI(), RoleReads, 500*time.Millisecond, nil)
+
+	got := c.instancesFor(RoleReads)
+	if got[0].GetURI() != "fake://reliable" {
+		t.Fatalf("expected the reliable-but-slow instance to rank first, got %v", got[0].GetURI())
+	}
+}
+
+func TestInstancesFor_FallsBackToAllInstancesWhenNoneDeclareRole(t *testing.T) {
+	a := &fakeHeadEventsInstance{uri: "fake://a", roles: []Role{RolePublish}}
+	b := &fakeHeadEventsInstance{uri: "fake://b", roles: []Role{RolePublish}}
+
+	c := NewMultiBeaconClient(logrus.NewEntry(logrus.New()), []IBeaconInstance{a, b})
+
+	got := c.instancesFor(RoleReads)
+	if len(got) != 2 {
+		t.Fatalf("expected fallback to all instances, got %d", len(got))
+	}
+}
+
+func TestInstancesFor_ExcludesInstancesNotDeclaringRole(t *testing.T) {
+	reads := &fakeHeadEventsInstance{uri: "fake://reads", roles: []Role{RoleReads}}
+	publish := &fakeHeadEventsInstance{uri: "fake://publish", roles: []Role{RolePublish}}
+
+	c := NewMultiBeaconClient(logrus.NewEntry(logrus.New()), []IBeaconInstance{reads, publish})
+
+	got := c.instancesFor(RoleReads)
+	if len(got) != 1 || got[0].GetURI() != "fake://reads" {
+		t.Fatalf("expected only the reads-role instance, got %v", got)
+	}
+}
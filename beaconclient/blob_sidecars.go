@@ -0,0 +1,90 @@
+package beaconclient
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/flashbots/mev-boost-relay/common"
+	"github.com/sirupsen/logrus"
+)
+
+// GetBlobSidecars returns the blob sidecars for a block, trying each reads-role beacon node
+// best-first by health score - https://ethereum.github.io/beacon-APIs/#/Beacon/getBlobSidecars
+func (c *MultiBeaconClient) GetBlobSidecars(blockID string, indices []uint64) (sidecars *GetBlobSidecarsResponse, err error) {
+	clients := c.instancesFor(RoleReads)
+	for _, client := range clients {
+		log := c.log.WithField("uri", client.GetURI())
+		start := time.Now()
+		sidecars, err = client.GetBlobSidecars(blockID, indices)
+		c.recordResult(client.GetURI(), RoleReads, time.Since(start), err)
+		if err != nil {
+			log.WithField("blockID", blockID).WithError(err).Warn("failed to get blob sidecars")
+			continue
+		}
+
+		return sidecars, nil
+	}
+
+	c.log.WithField("blockID", blockID).WithError(err).Error("failed to get blob sidecars from any CL node")
+	return nil, err
+}
+
+// PublishBlockWithBlobs publishes a post-Deneb signed beacon block together with its blobs and KZG
+// proofs to every beacon node, analogous to PublishBlock but using the v2 endpoint that accepts the
+// SignedBeaconBlockContents wrapper - https://ethereum.github.io/beacon-APIs/#/ValidatorRequiredApi/publishBlockV2
+func (c *MultiBeaconClient) PublishBlockWithBlobs(block *common.SignedBeaconBlockContents) (code int, err error) {
+	if c.forkTracker.requireForkAtLeast(block.Slot(), ForkDeneb) {
+		return 0, ErrBlobsBeforeDeneb
+	}
+
+	log := c.log.WithFields(logrus.Fields{
+		"slot":      block.Slot(),
+		"blockHash": block.BlockHash(),
+		"numBlobs":  block.NumBlobs(),
+	})
+
+	if digest, err := c.forkTracker.ForkDigest(block.Slot()); err == nil {
+		log = log.WithField("forkDigest", fmt.Sprintf("%x", digest))
+	}
+
+	clients := c.instancesFor(RolePublish)
+
+	// The chan will be cleaned up automatically once the function exits even if it was still being written to
+	resChans := make(chan publishResp, len(clients))
+
+	for i, client := range clients {
+		log := log.WithField("uri", client.GetURI())
+		log.Debug("publishing block with blobs")
+		go func(index int, client IBeaconInstance) {
+			start := time.Now()
+			code, err := client.PublishBlockWithBlobs(block)
+			c.recordResult(client.GetURI(), RolePublish, time.Since(start), err)
+			resChans <- publishResp{
+				index: index,
+				code:  code,
+				err:   err,
+			}
+		}(i, client)
+	}
+
+	var lastErrPublishResp publishResp
+	for i := 0; i < len(clients); i++ {
+		res := <-resChans
+		log = log.WithField("beacon", clients[res.index].GetURI())
+		if res.err != nil {
+			log.WithField("statusCode", res.code).WithError(res.err).Warn("failed to publish block with blobs")
+			lastErrPublishResp = res
+			continue
+		} else if res.code == 202 {
+			log.WithField("statusCode", res.code).WithError(res.err).Error("block failed validation but was still broadcast")
+			lastErrPublishResp = res
+			continue
+		}
+
+		log.WithField("statusCode", res.code).Info("published block with blobs")
+		return res.code, nil
+	}
+
+	log.Error("failed to publish block with blobs on any CL node")
+	return lastErrPublishResp.code, fmt.Errorf("last error: %w", lastErrPublishResp.err)
+}
@@ -0,0 +1,211 @@
+package beaconclient
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/flashbots/mev-boost-relay/common"
+	"github.com/sirupsen/logrus"
+)
+
+// Engine API auth-RPC configuration, following the geth/lighthouse convention of
+// authrpc.addr / authrpc.jwtsecret flags.
+const (
+	envEngineFallbackAddr      = "ENGINE_FALLBACK_AUTHRPC_ADDR"
+	envEngineFallbackJWTSecret = "ENGINE_FALLBACK_AUTHRPC_JWTSECRET"
+	engineFallbackHTTPTimeout  = 2 * time.Second
+)
+
+// ForkchoiceStateV1 mirrors the execution-layer Engine API type of the same name.
+type ForkchoiceStateV1 struct {
+	HeadBlockHash      string `json:"headBlockHash"`
+	SafeBlockHash      string `json:"safeBlockHash"`
+	FinalizedBlockHash string `json:"finalizedBlockHash"`
+}
+
+// EngineFallback talks directly to an execution client's authenticated Engine API, so the
+// relay can still land a block's execution payload when every configured beacon node fails to
+// broadcast it. It's an optional, best-effort last resort - not a replacement for the CL.
+type EngineFallback struct {
+	log        *logrus.Entry
+	addr       string
+	jwtSecret  []byte
+	httpClient *http.Client
+}
+
+// NewEngineFallback builds a fallback client from an authrpc.addr URL and a hex-encoded (with or
+// without 0x prefix) JWT secret, per the standard EL auth-RPC configuration.
+func NewEngineFallback(log *logrus.Entry, authRPCAddr, jwtSecretHex string) (*EngineFallback, error) {
+	secret, err := hex.DecodeString(strings.TrimPrefix(jwtSecretHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid engine fallback jwt secret: %w", err)
+	}
+
+	return &EngineFallback{
+		log:       log.WithField("component", "engineFallback"),
+		addr:      authRPCAddr,
+		jwtSecret: secret,
+		httpClient: &http.Client{
+			Timeout: engineFallbackHTTPTimeout,
+		},
+	}, nil
+}
+
+// EngineFallbackFromEnv returns an EngineFallback configured from ENGINE_FALLBACK_AUTHRPC_ADDR /
+// ENGINE_FALLBACK_AUTHRPC_JWTSECRET, or nil if unset (the feature is opt-in).
+func EngineFallbackFromEnv(log *logrus.Entry) (*EngineFallback, error) {
+	addr := os.Getenv(envEngineFallbackAddr)
+	secret := os.Getenv(envEngineFallbackJWTSecret)
+	if addr == "" || secret == "" {
+		return nil, nil
+	}
+	return NewEngineFallback(log, addr, secret)
+}
+
+// NewPayload calls the newPayload variant matching the block's fork - V1 pre-Capella, V2 Capella,
+// V3 Deneb+ (blob versioned hashes and parent beacon block root only exist from Deneb onward) -
+// with the block's execution payload, returning the EL's payload status. ForkUnknown (the fork
+// tracker isn't ready) falls back to V3, this fallback's original behavior before it became
+// fork-aware.
+func (e *EngineFallback) NewPayload(block *common.SignedBeaconBlock, fork ForkName) (status string, err error) {
+	method, params, err := newPayloadRequest(block, fork)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Status string `json:"status"`
+	}
+	if err := e.call(method, params, &result); err != nil {
+		return "", err
+	}
+
+	return result.Status, nil
+}
+
+// newPayloadRequest picks the newPayload JSON-RPC method and params for fork.
+func newPayloadRequest(block *common.SignedBeaconBlock, fork ForkName) (method string, params []interface{}, err error) {
+	switch fork {
+	case ForkBellatrix, ForkCapella:
+		payload, err := executionPayloadParamsV1(block)
+		if err != nil {
+			return "", nil, err
+		}
+		if fork == ForkCapella {
+			return "engine_newPayloadV2", []interface{}{payload}, nil
+		}
+		return "engine_newPayloadV1", []interface{}{payload}, nil
+	default:
+		payload, versionedHashes, parentBeaconBlockRoot, err := executionPayloadParamsV3(block)
+		if err != nil {
+			return "", nil, err
+		}
+		return "engine_newPayloadV3", []interface{}{payload, versionedHashes, parentBeaconBlockRoot}, nil
+	}
+}
+
+// ForkchoiceUpdated calls the forkchoiceUpdated variant matching fork (V1 pre-Capella, V2
+// Capella, V3 Deneb+ - the params are identical across versions, only the method name changes)
+// with the given ForkchoiceStateV1 (PublishBlock's fallback passes the new block's hash as head
+// and the hash of the last block observed via GetBlock as safe and finalized), without payload
+// attributes since this fallback only aims to land the payload, not build a new one. ForkUnknown
+// falls back to V3.
+func (e *EngineFallback) ForkchoiceUpdated(state ForkchoiceStateV1, fork ForkName) (status string, err error) {
+	method := "engine_forkchoiceUpdatedV3"
+	switch fork {
+	case ForkBellatrix:
+		method = "engine_forkchoiceUpdatedV1"
+	case ForkCapella:
+		method = "engine_forkchoiceUpdatedV2"
+	}
+
+	var result struct {
+		PayloadStatus struct {
+			Status string `json:"status"`
+		} `json:"payloadStatus"`
+	}
+	if err := e.call(method, []interface{}{state, nil}, &result); err != nil {
+		return "", err
+	}
+
+	return result.PayloadStatus.Status, nil
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type jsonRPCResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (e *EngineFallback) call(method string, params []interface{}, out interface{}) error {
+	reqBody, err := json.Marshal(jsonRPCRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, e.addr, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	token, err := e.signJWT()
+	if err != nil {
+		return fmt.Errorf("engine fallback: failed to sign jwt: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("engine fallback: %s request failed: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var rpcResp jsonRPCResponse
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return fmt.Errorf("engine fallback: failed to decode %s response: %w", method, err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("engine fallback: %s error %d: %s", method, rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+
+	return json.Unmarshal(rpcResp.Result, out)
+}
+
+// signJWT produces the HS256 JWT the Engine API auth-RPC expects: header+claims of
+// {"typ":"JWT","alg":"HS256"} / {"iat": <unix seconds>}, per EIP-3767.
+func (e *EngineFallback) signJWT() (string, error) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"HS256"}`))
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"iat":%d}`, time.Now().Unix())))
+
+	signingInput := header + "." + claims
+	mac := hmac.New(sha256.New, e.jwtSecret)
+	mac.Write([]byte(signingInput))
+	signature := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	return signingInput + "." + signature, nil
+}
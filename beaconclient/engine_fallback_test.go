@@ -0,0 +1,111 @@
+package beaconclient
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestSignJWT_HeaderAndClaims(t *testing.T) {
+	e := &EngineFallback{jwtSecret: []byte("test-secret")}
+
+	token, err := e.signJWT()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected a 3-part JWT, got %d parts", len(parts))
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		t.Fatalf("failed to decode header: %v", err)
+	}
+	var header struct {
+		Typ string `json:"typ"`
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		t.Fatalf("failed to unmarshal header: %v", err)
+	}
+	if header.Typ != "JWT" || header.Alg != "HS256" {
+		t.Fatalf("expected typ=JWT alg=HS256, got %+v", header)
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("failed to decode claims: %v", err)
+	}
+	var claims struct {
+		IAT int64 `json:"iat"`
+	}
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		t.Fatalf("failed to unmarshal claims: %v", err)
+	}
+	if claims.IAT == 0 {
+		t.Fatal("expected a non-zero iat claim")
+	}
+
+	mac := hmac.New(sha256.New, e.jwtSecret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	wantSig := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	if parts[2] != wantSig {
+		t.Fatalf("signature does not verify against jwtSecret: got %s, want %s", parts[2], wantSig)
+	}
+}
+
+// TestForkchoiceUpdated_PicksMethodByFork guards against engine_fallback.go reverting to an
+// unconditional engine_forkchoiceUpdatedV3 call regardless of the block's actual fork.
+func TestForkchoiceUpdated_PicksMethodByFork(t *testing.T) {
+	tests := []struct {
+		fork       ForkName
+		wantMethod string
+	}{
+		{ForkBellatrix, "engine_forkchoiceUpdatedV1"},
+		{ForkCapella, "engine_forkchoiceUpdatedV2"},
+		{ForkDeneb, "engine_forkchoiceUpdatedV3"},
+		{ForkElectra, "engine_forkchoiceUpdatedV3"},
+		{ForkUnknown, "engine_forkchoiceUpdatedV3"},
+	}
+
+	for _, tt := range tests {
+		var gotMethod string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req jsonRPCRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			gotMethod = req.Method
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"payloadStatus":{"status":"VALID"}}}`))
+		}))
+
+		e := &EngineFallback{
+			log:        logrus.NewEntry(logrus.New()),
+			addr:       server.URL,
+			jwtSecret:  []byte("test-secret"),
+			httpClient: http.DefaultClient,
+		}
+
+		status, err := e.ForkchoiceUpdated(ForkchoiceStateV1{HeadBlockHash: "0xa"}, tt.fork)
+		server.Close()
+		if err != nil {
+			t.Fatalf("fork %q: unexpected error: %v", tt.fork, err)
+		}
+		if status != "VALID" {
+			t.Fatalf("fork %q: expected status VALID, got %q", tt.fork, status)
+		}
+		if gotMethod != tt.wantMethod {
+			t.Fatalf("fork %q: expected method %s, got %s", tt.fork, tt.wantMethod, gotMethod)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package beaconclient
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/flashbots/mev-boost-relay/common"
+)
+
+// versionedHashVersion is the single-byte version prefix for KZG-commitment versioned hashes,
+// per EIP-4844.
+const versionedHashVersion = 0x01
+
+// executionPayloadParamsV1 builds the engine_newPayloadV1 and engine_newPayloadV2 parameters from a
+// signed beacon block: just the execution payload itself, as pre-Deneb has no blobs to carry
+// alongside it (Capella withdrawals travel inside the payload).
+func executionPayloadParamsV1(block *common.SignedBeaconBlock) (payload interface{}, err error) {
+	execPayload := block.ExecutionPayload()
+	if execPayload == nil {
+		return nil, fmt.Errorf("engine fallback: block has no execution payload")
+	}
+
+	return execPayload, nil
+}
+
+// executionPayloadParamsV3 builds the engine_newPayloadV3 parameters from a signed beacon block:
+// the execution payload itself, the versioned hashes derived from its blob KZG commitments, and
+// the parent beacon block root.
+func executionPayloadParamsV3(block *common.SignedBeaconBlock) (payload interface{}, versionedHashes []string, parentBeaconBlockRoot string, err error) {
+	execPayload := block.ExecutionPayload()
+	if execPayload == nil {
+		return nil, nil, "", fmt.Errorf("engine fallback: block has no execution payload")
+	}
+
+	commitments := block.BlobKZGCommitments()
+	versionedHashes = make([]string, len(commitments))
+	for i, commitment := range commitments {
+		versionedHashes[i] = versionedHashFromCommitment(commitment)
+	}
+
+	return execPayload, versionedHashes, block.ParentRoot(), nil
+}
+
+// versionedHashFromCommitment implements kzg_to_versioned_hash: sha256(commitment) with the
+// first byte overwritten by the version prefix.
+func versionedHashFromCommitment(commitment []byte) string {
+	sum := sha256.Sum256(commitment)
+	sum[0] = versionedHashVersion
+	return fmt.Sprintf("0x%x", sum)
+}
@@ -0,0 +1,53 @@
+package beaconclient
+
+import (
+	"container/list"
+	"sync"
+)
+
+// eventDedupCapacity bounds how many recent event keys we remember per subscription kind.
+// Head events and payload-attributes events arrive roughly once per slot per beacon
+// instance, so a few dozen entries comfortably covers the fan-in window.
+const eventDedupCapacity = 64
+
+// dedupLRU is a small fixed-capacity set used to recognize events we've already forwarded,
+// so that fanning the same SSE stream in from N beacon instances doesn't deliver N copies
+// of every slot downstream.
+type dedupLRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	index    map[string]*list.Element
+}
+
+func newDedupLRU(capacity int) *dedupLRU {
+	return &dedupLRU{
+		capacity: capacity,
+		order:    list.New(),
+		index:    make(map[string]*list.Element),
+	}
+}
+
+// seen returns true if key was already recorded, and records it as seen if not.
+func (d *dedupLRU) seen(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.index[key]; ok {
+		return true
+	}
+
+	elem := d.order.PushFront(key)
+	d.index[key] = elem
+
+	for d.order.Len() > d.capacity {
+		oldest := d.order.Back()
+		if oldest == nil {
+			break
+		}
+		d.order.Remove(oldest)
+		delete(d.index, oldest.Value.(string))
+	}
+
+	return false
+}
@@ -0,0 +1,247 @@
+package beaconclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ForkName identifies a consensus-layer hard fork milestone relevant to block/withdrawal/blob handling.
+type ForkName string
+
+const (
+	ForkUnknown   ForkName = ""
+	ForkBellatrix ForkName = "bellatrix"
+	ForkCapella   ForkName = "capella"
+	ForkDeneb     ForkName = "deneb"
+	ForkElectra   ForkName = "electra"
+)
+
+// forkOrder lists forks oldest-to-newest so CurrentFork can pick the latest milestone whose
+// epoch has been reached.
+var forkOrder = []ForkName{ForkBellatrix, ForkCapella, ForkDeneb, ForkElectra}
+
+// forkMilestone is a single entry of the fork schedule, as returned by GetForkSchedule.
+type forkMilestone struct {
+	name           ForkName
+	epoch          uint64
+	currentVersion [4]byte
+}
+
+// ForkTracker caches the genesis info, spec, and fork schedule so callers can resolve the
+// active fork for a given slot without re-deriving it or string-matching CL error messages.
+type ForkTracker struct {
+	mu sync.RWMutex
+
+	genesisValidatorsRoot [32]byte
+	slotsPerEpoch         uint64
+	milestones            []forkMilestone
+	ready                 bool
+}
+
+// NewForkTracker returns an empty, unpopulated tracker. Call Start to fetch genesis/spec/fork-schedule.
+func NewForkTracker() *ForkTracker {
+	return &ForkTracker{
+		slotsPerEpoch: 32, // mainnet default, overwritten by GetSpec once Start succeeds
+	}
+}
+
+// Start fetches GetGenesis, GetSpec, and GetForkSchedule from the given client and populates
+// the tracker. It should be called once at startup, before CurrentFork is relied upon.
+func (t *ForkTracker) Start(c *MultiBeaconClient) error {
+	genesis, err := c.GetGenesis()
+	if err != nil {
+		return fmt.Errorf("fork tracker: failed to get genesis: %w", err)
+	}
+
+	spec, err := c.GetSpec()
+	if err != nil {
+		return fmt.Errorf("fork tracker: failed to get spec: %w", err)
+	}
+
+	schedule, err := c.GetForkSchedule()
+	if err != nil {
+		return fmt.Errorf("fork tracker: failed to get fork schedule: %w", err)
+	}
+
+	root, err := hexToBytes32(genesis.Data.GenesisValidatorsRoot)
+	if err != nil {
+		return fmt.Errorf("fork tracker: invalid genesis_validators_root: %w", err)
+	}
+
+	slotsPerEpoch := uint64(32)
+	if raw, ok := spec.Data["SLOTS_PER_EPOCH"]; ok {
+		if v, err := strconv.ParseUint(fmt.Sprintf("%v", raw), 10, 64); err == nil {
+			slotsPerEpoch = v
+		}
+	}
+
+	milestones := make([]forkMilestone, 0, len(schedule.Data))
+	for _, entry := range schedule.Data {
+		name := forkNameForVersion(entry.CurrentVersion)
+		if name == ForkUnknown {
+			continue
+		}
+
+		epoch, err := strconv.ParseUint(entry.Epoch, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		version, err := hexToBytes4(entry.CurrentVersion)
+		if err != nil {
+			continue
+		}
+
+		milestones = append(milestones, forkMilestone{name: name, epoch: epoch, currentVersion: version})
+	}
+
+	sort.Slice(milestones, func(i, j int) bool { return milestones[i].epoch < milestones[j].epoch })
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.genesisValidatorsRoot = root
+	t.slotsPerEpoch = slotsPerEpoch
+	t.milestones = milestones
+	t.ready = true
+
+	return nil
+}
+
+// CurrentFork returns the fork active at the given slot, or ForkUnknown if the tracker hasn't
+// been populated yet or the slot precedes every known milestone.
+func (t *ForkTracker) CurrentFork(slot uint64) ForkName {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.ready || t.slotsPerEpoch == 0 {
+		return ForkUnknown
+	}
+
+	epoch := slot / t.slotsPerEpoch
+
+	active := ForkUnknown
+	for _, m := range t.milestones {
+		if m.epoch <= epoch {
+			active = m.name
+		}
+	}
+	return active
+}
+
+// ForkDigest returns compute_fork_digest(current_version, genesis_validators_root) for the fork
+// active at slot, so callers can tag/validate requests by the network's actual digest instead of
+// just comparing fork names. It errors if the tracker hasn't been populated yet or the slot
+// precedes every known milestone.
+func (t *ForkTracker) ForkDigest(slot uint64) ([4]byte, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.ready || t.slotsPerEpoch == 0 {
+		return [4]byte{}, fmt.Errorf("fork tracker: not ready")
+	}
+
+	epoch := slot / t.slotsPerEpoch
+
+	var active *forkMilestone
+	for i := range t.milestones {
+		if t.milestones[i].epoch <= epoch {
+			active = &t.milestones[i]
+		}
+	}
+	if active == nil {
+		return [4]byte{}, fmt.Errorf("fork tracker: no milestone covers slot %d", slot)
+	}
+
+	return computeForkDigest(active.currentVersion, t.genesisValidatorsRoot), nil
+}
+
+// requireForkAtLeast reports whether slot precedes minFork, i.e. a fork-gated feature should be
+// rejected for it. It returns false (don't reject) whenever the fork tracker can't yet resolve a
+// fork for slot, leaving the caller's own backstop (e.g. string-matching the CL's error) to catch
+// the case where the pre-flight check couldn't short-circuit.
+func (t *ForkTracker) requireForkAtLeast(slot uint64, minFork ForkName) bool {
+	fork := t.CurrentFork(slot)
+	return fork != ForkUnknown && !AtLeast(fork, minFork)
+}
+
+// AtLeast reports whether fork is at or after minFork in the canonical fork order.
+func AtLeast(fork, minFork ForkName) bool {
+	fi, fj := -1, -1
+	for i, f := range forkOrder {
+		if f == fork {
+			fi = i
+		}
+		if f == minFork {
+			fj = i
+		}
+	}
+	return fi >= 0 && fj >= 0 && fi >= fj
+}
+
+// computeForkDigest implements compute_fork_digest(current_version, genesis_validators_root)
+// from the consensus spec: the first 4 bytes of hash_tree_root(ForkData(current_version, genesis_validators_root)).
+// ForkData has two fixed-size fields, each its own SSZ chunk: current_version is right-padded
+// with zeros to a 32-byte chunk, then hash-tree-root is the SHA-256 of that chunk concatenated
+// with genesis_validators_root (already 32 bytes).
+func computeForkDigest(currentVersion [4]byte, genesisValidatorsRoot [32]byte) [4]byte {
+	var versionChunk [32]byte
+	copy(versionChunk[:], currentVersion[:])
+
+	data := make([]byte, 0, 64)
+	data = append(data, versionChunk[:]...)
+	data = append(data, genesisValidatorsRoot[:]...)
+
+	sum := sha256.Sum256(data)
+
+	var digest [4]byte
+	copy(digest[:], sum[:4])
+	return digest
+}
+
+// forkNameForVersion is a best-effort mapping from known mainnet fork-version prefixes to a
+// ForkName; unrecognized versions (custom/devnets) are skipped rather than guessed at.
+func forkNameForVersion(hexVersion string) ForkName {
+	switch strings.ToLower(strings.TrimPrefix(hexVersion, "0x")) {
+	case "02000000":
+		return ForkBellatrix
+	case "03000000":
+		return ForkCapella
+	case "04000000":
+		return ForkDeneb
+	case "05000000":
+		return ForkElectra
+	default:
+		return ForkUnknown
+	}
+}
+
+func hexToBytes32(s string) ([32]byte, error) {
+	var out [32]byte
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return out, err
+	}
+	if len(b) != 32 {
+		return out, fmt.Errorf("expected 32 bytes, got %d", len(b))
+	}
+	copy(out[:], b)
+	return out, nil
+}
+
+func hexToBytes4(s string) ([4]byte, error) {
+	var out [4]byte
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return out, err
+	}
+	if len(b) != 4 {
+		return out, fmt.Errorf("expected 4 bytes, got %d", len(b))
+	}
+	copy(out[:], b)
+	return out, nil
+}
@@ -0,0 +1,74 @@
+package beaconclient
+
+import "testing"
+
+func TestForkTracker_NotReady(t *testing.T) {
+	tracker := NewForkTracker()
+
+	if fork := tracker.CurrentFork(100); fork != ForkUnknown {
+		t.Fatalf("expected ForkUnknown before Start, got %q", fork)
+	}
+	if _, err := tracker.ForkDigest(100); err == nil {
+		t.Fatal("expected ForkDigest to error before Start")
+	}
+}
+
+func TestForkTracker_CurrentForkAndDigest(t *testing.T) {
+	tracker := NewForkTracker()
+	tracker.genesisValidatorsRoot = [32]byte{0x01}
+	tracker.slotsPerEpoch = 32
+	tracker.milestones = []forkMilestone{
+		{name: ForkBellatrix, epoch: 0, currentVersion: [4]byte{0x02, 0, 0, 0}},
+		{name: ForkCapella, epoch: 10, currentVersion: [4]byte{0x03, 0, 0, 0}},
+		{name: ForkDeneb, epoch: 20, currentVersion: [4]byte{0x04, 0, 0, 0}},
+	}
+	tracker.ready = true
+
+	tests := []struct {
+		slot     uint64
+		wantFork ForkName
+	}{
+		{slot: 0, wantFork: ForkBellatrix},
+		{slot: 10 * 32, wantFork: ForkCapella},
+		{slot: 10*32 - 1, wantFork: ForkBellatrix},
+		{slot: 20 * 32, wantFork: ForkDeneb},
+	}
+	for _, tt := range tests {
+		if got := tracker.CurrentFork(tt.slot); got != tt.wantFork {
+			t.Errorf("CurrentFork(%d) = %q, want %q", tt.slot, got, tt.wantFork)
+		}
+	}
+
+	bellatrixDigest, err := tracker.ForkDigest(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	capellaDigest, err := tracker.ForkDigest(10 * 32)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bellatrixDigest == capellaDigest {
+		t.Fatal("expected digests for different forks to differ")
+	}
+
+	// Same fork, same slot should be deterministic.
+	again, err := tracker.ForkDigest(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again != bellatrixDigest {
+		t.Fatalf("ForkDigest is not deterministic: %x != %x", again, bellatrixDigest)
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	if !AtLeast(ForkDeneb, ForkCapella) {
+		t.Fatal("expected deneb to be at least capella")
+	}
+	if AtLeast(ForkCapella, ForkDeneb) {
+		t.Fatal("expected capella to not be at least deneb")
+	}
+	if AtLeast(ForkUnknown, ForkCapella) {
+		t.Fatal("expected ForkUnknown to never satisfy AtLeast")
+	}
+}
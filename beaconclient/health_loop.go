@@ -0,0 +1,77 @@
+package beaconclient
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// defaultHealthLoopInterval is how often HealthLoop polls SyncStatus/head slot across the pool.
+const defaultHealthLoopInterval = 6 * time.Second
+
+// HealthLoop periodically polls each beacon instance's sync status and demotes any instance
+// whose head slot trails the pool median by more than maxHeadSlotLag slots, across all roles.
+// It mirrors the multi-BN fallback strategy used by validator clients: stay on the hot set of
+// beacons that are actually near the chain head, and stop routing traffic to ones that fell behind.
+func (c *MultiBeaconClient) HealthLoop(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultHealthLoopInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkInstanceHealth()
+		}
+	}
+}
+
+func (c *MultiBeaconClient) checkInstanceHealth() {
+	type headSlot struct {
+		instance IBeaconInstance
+		slot     uint64
+		ok       bool
+	}
+
+	slots := make([]headSlot, len(c.beaconInstances))
+	for i, instance := range c.beaconInstances {
+		slot, err := instance.CurrentSlot()
+		if err != nil {
+			log := c.log.WithField("uri", instance.GetURI())
+			log.WithError(err).Warn("healthLoop: failed to get current slot")
+			slots[i] = headSlot{instance: instance, ok: false}
+			continue
+		}
+		slots[i] = headSlot{instance: instance, slot: slot, ok: true}
+	}
+
+	var known []uint64
+	for _, s := range slots {
+		if s.ok {
+			known = append(known, s.slot)
+		}
+	}
+	if len(known) == 0 {
+		return
+	}
+
+	sort.Slice(known, func(i, j int) bool { return known[i] < known[j] })
+	median := known[len(known)/2]
+
+	for _, s := range slots {
+		demoted := !s.ok || median > s.slot && median-s.slot > maxHeadSlotLag
+		for _, role := range []Role{RolePublish, RoleDuties, RoleEvents, RoleReads} {
+			c.pool.scoreFor(s.instance.GetURI(), role).setDemoted(demoted)
+		}
+
+		if demoted {
+			c.log.WithField("uri", s.instance.GetURI()).WithField("slot", s.slot).WithField("median", median).
+				Warn("healthLoop: demoting beacon instance, head slot trails pool median")
+		}
+	}
+}
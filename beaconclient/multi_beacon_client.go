@@ -2,22 +2,25 @@
 package beaconclient
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/flashbots/go-boost-utils/types"
 	"github.com/flashbots/mev-boost-relay/common"
 	"github.com/sirupsen/logrus"
-	uberatomic "go.uber.org/atomic"
 )
 
 var (
 	ErrBeaconNodeSyncing        = errors.New("beacon node is syncing or unavailable")
 	ErrBeaconNodesUnavailable   = errors.New("all beacon nodes responded with error")
 	ErrWithdrawalsBeforeCapella = errors.New("withdrawals are not supported before capella")
+	ErrBlobsBeforeDeneb         = errors.New("blobs are not supported before deneb")
 	ErrBeaconBlock202           = errors.New("beacon block failed validation but was still broadcast (202)")
 )
 
@@ -31,13 +34,33 @@ type IMultiBeaconClient interface {
 	// GetStateValidators returns all active and pending validators from the beacon node
 	GetStateValidators(stateID string) (map[types.PubkeyHex]ValidatorResponseEntry, error)
 	GetProposerDuties(epoch uint64) (*ProposerDutiesResponse, error)
-	PublishBlock(block *common.SignedBeaconBlock) (code int, err error)
+
+	// PublishBlock publishes the signed beacon block, returning as soon as quorum acknowledgements
+	// are received (or ctx is done). quorum <= 0 defaults to 1. Remaining broadcasts continue in the
+	// background for redundancy and are reflected in the returned PublishReport.
+	PublishBlock(ctx context.Context, block *common.SignedBeaconBlock, quorum int) (*PublishReport, error)
 	GetGenesis() (*GetGenesisResponse, error)
 	GetSpec() (spec *GetSpecResponse, err error)
 	GetForkSchedule() (spec *GetForkScheduleResponse, err error)
 	GetBlock(blockID string) (block *GetBlockResponse, err error)
 	GetRandao(slot uint64) (spec *GetRandaoResponse, err error)
 	GetWithdrawals(slot uint64) (spec *GetWithdrawalsResponse, err error)
+
+	// StreamStatus reports last-event-age and reconnect count per beacon instance URI, for
+	// both the head-event and payload-attributes subscriptions.
+	StreamStatus() map[string]StreamHealth
+
+	// GetValidatorLiveness returns, for each requested validator index, whether it was seen
+	// proposing/attesting during epoch. https://ethereum.github.io/beacon-APIs/#/Validator/getLiveness
+	GetValidatorLiveness(epoch uint64, indices []uint64) (map[uint64]bool, error)
+
+	// GetBlobSidecars returns the blob sidecars for a given block, optionally filtered to specific indices.
+	// https://ethereum.github.io/beacon-APIs/#/Beacon/getBlobSidecars
+	GetBlobSidecars(blockID string, indices []uint64) (*GetBlobSidecarsResponse, error)
+
+	// PublishBlockWithBlobs publishes a post-Deneb signed beacon block together with its blobs and KZG proofs.
+	// https://ethereum.github.io/beacon-APIs/#/ValidatorRequiredApi/publishBlockV2
+	PublishBlockWithBlobs(block *common.SignedBeaconBlockContents) (code int, err error)
 }
 
 // IBeaconInstance is the interface for a single beacon client instance
@@ -46,32 +69,93 @@ type IBeaconInstance interface {
 	CurrentSlot() (uint64, error)
 	SubscribeToHeadEvents(slotC chan HeadEventData)
 	SubscribeToPayloadAttributesEvents(slotC chan PayloadAttributesEvent)
-	GetStateValidators(stateID string) (map[types.PubkeyHex]ValidatorResponseEntry, error)
+	// GetStateValidators sends accept as the Accept header (see ssz.go's acceptHeader). It
+	// returns an *ErrUnsupportedContentType if the CL rejects it with 415/406; callers should
+	// retry once with contentTypeJSON.
+	GetStateValidators(stateID string, accept string) (map[types.PubkeyHex]ValidatorResponseEntry, error)
 	GetProposerDuties(epoch uint64) (*ProposerDutiesResponse, error)
 	GetURI() string
-	PublishBlock(block *common.SignedBeaconBlock) (code int, err error)
+
+	// PublishBlock sends the signed beacon block with a Content-Type of contentType (see
+	// ssz.go's contentTypeJSON/contentTypeSSZ). Callers that get back a 415/406 should retry
+	// once with contentTypeJSON.
+	PublishBlock(ctx context.Context, block *common.SignedBeaconBlock, contentType string) (code int, err error)
 	GetGenesis() (*GetGenesisResponse, error)
 	GetSpec() (spec *GetSpecResponse, err error)
 	GetForkSchedule() (spec *GetForkScheduleResponse, err error)
-	GetBlock(blockID string) (*GetBlockResponse, error)
-	GetRandao(slot uint64) (spec *GetRandaoResponse, err error)
-	GetWithdrawals(slot uint64) (spec *GetWithdrawalsResponse, err error)
+
+	// GetBlock, GetRandao and GetWithdrawals send accept as the Accept header (see
+	// ssz.go's acceptHeader). They return an *ErrUnsupportedContentType if the CL rejects it
+	// with 415/406; callers should retry once with contentTypeJSON.
+	GetBlock(blockID string, accept string) (*GetBlockResponse, error)
+	GetRandao(slot uint64, accept string) (spec *GetRandaoResponse, err error)
+	GetWithdrawals(slot uint64, accept string) (spec *GetWithdrawalsResponse, err error)
+	GetValidatorLiveness(epoch uint64, indices []uint64) (map[uint64]bool, error)
+	GetBlobSidecars(blockID string, indices []uint64) (*GetBlobSidecarsResponse, error)
+	PublishBlockWithBlobs(block *common.SignedBeaconBlockContents) (code int, err error)
+
+	// PreferSSZ reports whether this instance should be asked for SSZ-encoded
+	// responses (Accept: application/octet-stream) instead of JSON. Callers
+	// that get back a 415/406 should fall back to JSON and call SetPreferSSZ(false).
+	// Implementations must be safe for concurrent use: PublishBlock, GetBlock, GetRandao,
+	// GetWithdrawals and GetStateValidators may all read/write this flag on the same instance
+	// from different goroutines at once.
+	PreferSSZ() bool
+	SetPreferSSZ(prefer bool)
+
+	// Roles reports which operation families this instance should serve (see Role).
+	// An empty slice means the instance serves every role, which is the right default
+	// for a single-beacon setup.
+	Roles() []Role
 }
 
 type MultiBeaconClient struct {
 	log             *logrus.Entry
-	bestBeaconIndex uberatomic.Int64
+	pool            *beaconPool
 	beaconInstances []IBeaconInstance
 
+	headEventDedup   *dedupLRU
+	payloadAttrDedup *dedupLRU
+	streamHealthMu   sync.Mutex
+	streamHealth     map[string]*streamHealthState
+
+	forkTracker *ForkTracker
+
+	engineFallback *EngineFallback
+	lastBlockMu    sync.Mutex
+	lastBlock      *GetBlockResponse
+
+	livenessTracker *LivenessTracker
+
+	cache *readCache
+
 	// feature flags
 	ffAllowSyncingBeaconNode bool
 }
 
+// SetEngineFallback configures an optional Engine API fallback that MultiBeaconClient uses to
+// land a block's execution payload directly against an EL when every beacon node fails to
+// publish it. Pass nil to disable it.
+func (c *MultiBeaconClient) SetEngineFallback(ef *EngineFallback) {
+	c.engineFallback = ef
+}
+
+// SetLivenessTracker configures an optional LivenessTracker that GetProposerDuties consults to
+// drop duties for proposers sampled offline. Pass nil to disable it (duties are served unfiltered).
+func (c *MultiBeaconClient) SetLivenessTracker(lt *LivenessTracker) {
+	c.livenessTracker = lt
+}
+
 func NewMultiBeaconClient(log *logrus.Entry, beaconInstances []IBeaconInstance) *MultiBeaconClient {
 	client := &MultiBeaconClient{
 		log:                      log.WithField("component", "beaconClient"),
 		beaconInstances:          beaconInstances,
-		bestBeaconIndex:          *uberatomic.NewInt64(0),
+		pool:                     newBeaconPool(),
+		headEventDedup:           newDedupLRU(eventDedupCapacity),
+		payloadAttrDedup:         newDedupLRU(eventDedupCapacity),
+		streamHealth:             make(map[string]*streamHealthState),
+		forkTracker:              NewForkTracker(),
+		cache:                    newReadCache(),
 		ffAllowSyncingBeaconNode: false,
 	}
 
@@ -136,151 +220,190 @@ func (c *MultiBeaconClient) BestSyncStatus() (*SyncStatusPayloadData, error) {
 	return bestSyncStatus, nil
 }
 
-// SubscribeToHeadEvents subscribes to head events from all beacon nodes. A single head event will be received multiple times,
-// likely once for every beacon nodes.
+// SubscribeToHeadEvents subscribes to head events from all beacon nodes, deduplicating by
+// (slot, block_root) before forwarding to slotC so a downstream consumer sees each slot once
+// no matter how many beacon instances are connected.
 func (c *MultiBeaconClient) SubscribeToHeadEvents(slotC chan HeadEventData) {
 	for _, instance := range c.beaconInstances {
-		go instance.SubscribeToHeadEvents(slotC)
-	}
-}
-
-func (c *MultiBeaconClient) SubscribeToPayloadAttributesEvents(slotC chan PayloadAttributesEvent) {
-	for _, instance := range c.beaconInstances {
-		go instance.SubscribeToPayloadAttributesEvents(slotC)
+		go c.subscribeToHeadEventsWithReconnect(instance, slotC)
 	}
 }
 
-func (c *MultiBeaconClient) GetStateValidators(stateID string) (map[types.PubkeyHex]ValidatorResponseEntry, error) {
-	// return the first successful beacon node response
-	clients := c.beaconInstancesByLastResponse()
-
-	for i, client := range clients {
-		log := c.log.WithField("uri", client.GetURI())
-		log.Debug("fetching validators")
-
-		validators, err := client.GetStateValidators(stateID)
-		if err != nil {
-			log.WithError(err).Error("failed to fetch validators")
-			continue
+func (c *MultiBeaconClient) subscribeToHeadEventsWithReconnect(instance IBeaconInstance, slotC chan HeadEventData) {
+	health := c.streamHealthFor(instance.GetURI())
+
+	backoff := initialStreamBackoff
+	for {
+		// A fresh channel is required on every reconnect attempt: instance.SubscribeToHeadEvents
+		// closes its channel when the SSE connection drops, and handing that closed channel to a
+		// new producer goroutine would panic on its first send.
+		internalC := make(chan HeadEventData)
+		streamingBeaconsGauge.Inc()
+		go instance.SubscribeToHeadEvents(internalC)
+
+		for event := range internalC {
+			health.recordEvent()
+			key := fmt.Sprintf("%d-%s", event.Slot, event.Block)
+			if c.headEventDedup.seen(key) {
+				continue
+			}
+			c.cache.onHeadEvent(event)
+			slotC <- event
 		}
 
-		c.bestBeaconIndex.Store(int64(i))
-
-		// Received successful response. Set this index as last successful beacon node
-		return validators, nil
+		// instance.SubscribeToHeadEvents returned, meaning the underlying SSE connection
+		// dropped (EOF/timeout). Reconnect with exponential backoff.
+		streamingBeaconsGauge.Dec()
+		health.recordReconnect()
+		c.log.WithField("uri", instance.GetURI()).WithField("backoff", backoff).Warn("head events stream disconnected, reconnecting")
+		time.Sleep(backoff)
+		backoff = nextStreamBackoff(backoff)
 	}
-
-	return nil, ErrBeaconNodesUnavailable
 }
 
-func (c *MultiBeaconClient) GetProposerDuties(epoch uint64) (*ProposerDutiesResponse, error) {
-	// return the first successful beacon node response
-	clients := c.beaconInstancesByLastResponse()
-	log := c.log.WithField("epoch", epoch)
-
-	for i, client := range clients {
-		log := log.WithField("uri", client.GetURI())
-		log.Debug("fetching proposer duties")
+// SubscribeToPayloadAttributesEvents subscribes to payload attributes events to validate fields such
+// as prevrandao and withdrawals, deduplicating by (slot, parent_block_root, proposer_index).
+func (c *MultiBeaconClient) SubscribeToPayloadAttributesEvents(payloadAttrC chan PayloadAttributesEvent) {
+	for _, instance := range c.instancesFor(RoleEvents) {
+		go c.subscribeToPayloadAttributesEventsWithReconnect(instance, payloadAttrC)
+	}
+}
 
-		duties, err := client.GetProposerDuties(epoch)
-		if err != nil {
-			log.WithError(err).Error("failed to get proposer duties")
-			continue
+func (c *MultiBeaconClient) subscribeToPayloadAttributesEventsWithReconnect(instance IBeaconInstance, payloadAttrC chan PayloadAttributesEvent) {
+	health := c.streamHealthFor(instance.GetURI())
+
+	backoff := initialStreamBackoff
+	for {
+		// See subscribeToHeadEventsWithReconnect: a fresh channel is required on every
+		// reconnect, since the old one was closed by the dropped SSE connection.
+		internalC := make(chan PayloadAttributesEvent)
+		go instance.SubscribeToPayloadAttributesEvents(internalC)
+
+		for event := range internalC {
+			health.recordEvent()
+			key := fmt.Sprintf("%d-%s-%d", event.Data.ProposalSlot, event.Data.ParentBlockRoot, event.Data.ProposerIndex)
+			if c.payloadAttrDedup.seen(key) {
+				continue
+			}
+			payloadAttrC <- event
 		}
 
-		c.bestBeaconIndex.Store(int64(i))
-
-		// Received successful response. Set this index as last successful beacon node
-		return duties, nil
+		health.recordReconnect()
+		c.log.WithField("uri", instance.GetURI()).WithField("backoff", backoff).Warn("payload attributes stream disconnected, reconnecting")
+		time.Sleep(backoff)
+		backoff = nextStreamBackoff(backoff)
 	}
-
-	return nil, ErrBeaconNodesUnavailable
 }
 
-// beaconInstancesByLastResponse returns a list of beacon clients that has the client
-// with the last successful response as the first element of the slice
-func (c *MultiBeaconClient) beaconInstancesByLastResponse() []IBeaconInstance {
-	index := c.bestBeaconIndex.Load()
-	if index == 0 {
-		return c.beaconInstances
-	}
+func (c *MultiBeaconClient) GetStateValidators(stateID string) (map[types.PubkeyHex]ValidatorResponseEntry, error) {
+	return c.cachedGetStateValidators(stateID, func() (map[types.PubkeyHex]ValidatorResponseEntry, error) {
+		// return the first successful beacon node response
+		clients := c.instancesFor(RoleReads)
+
+		for _, client := range clients {
+			log := c.log.WithField("uri", client.GetURI())
+			log.Debug("fetching validators")
+
+			start := time.Now()
+			validators, err := client.GetStateValidators(stateID, acceptHeader(client.PreferSSZ()))
+			if isUnsupportedMediaTypeErr(err) {
+				client.SetPreferSSZ(false)
+				validators, err = client.GetStateValidators(stateID, contentTypeJSON)
+			}
+			c.recordResult(client.GetURI(), RoleReads, time.Since(start), err)
+			if err != nil {
+				log.WithError(err).Error("failed to fetch validators")
+				continue
+			}
 
-	instances := make([]IBeaconInstance, len(c.beaconInstances))
-	copy(instances, c.beaconInstances)
-	instances[0], instances[index] = instances[index], instances[0]
+			return validators, nil
+		}
 
-	return instances
+		return nil, ErrBeaconNodesUnavailable
+	})
 }
 
-type publishResp struct {
-	index int
-	code  int
-	err   error
-}
+func (c *MultiBeaconClient) GetProposerDuties(epoch uint64) (*ProposerDutiesResponse, error) {
+	duties, err := c.cachedGetProposerDuties(epoch, func() (*ProposerDutiesResponse, error) {
+		// return the first successful beacon node response
+		clients := c.instancesFor(RoleDuties)
+		log := c.log.WithField("epoch", epoch)
+
+		for _, client := range clients {
+			log := log.WithField("uri", client.GetURI())
+			log.Debug("fetching proposer duties")
+
+			start := time.Now()
+			duties, err := client.GetProposerDuties(epoch)
+			c.recordResult(client.GetURI(), RoleDuties, time.Since(start), err)
+			if err != nil {
+				log.WithError(err).Error("failed to get proposer duties")
+				continue
+			}
 
-// PublishBlock publishes the signed beacon block via https://ethereum.github.io/beacon-APIs/#/ValidatorRequiredApi/publishBlock
-func (c *MultiBeaconClient) PublishBlock(block *common.SignedBeaconBlock) (code int, err error) {
-	log := c.log.WithFields(logrus.Fields{
-		"slot":      block.Slot(),
-		"blockHash": block.BlockHash(),
-	})
+			return duties, nil
+		}
 
-	clients := c.beaconInstancesByLastResponse()
+		return nil, ErrBeaconNodesUnavailable
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	// The chan will be cleaner up automatically once the function exists even if it was still being written to
-	resChans := make(chan publishResp, len(clients))
+	return c.skipOfflineProposerDuties(duties), nil
+}
 
-	for i, client := range clients {
-		log := log.WithField("uri", client.GetURI())
-		log.Debug("publishing block")
-		go func(index int, client IBeaconInstance) {
-			code, err := client.PublishBlock(block)
-			resChans <- publishResp{
-				index: index,
-				code:  code,
-				err:   err,
-			}
-		}(i, client)
+// skipOfflineProposerDuties drops duties for proposers LivenessTracker.ShouldSkip reports as
+// offline, so the relay stops serving getHeader to validators that are demonstrably not around to
+// use it. Returns duties unchanged if no LivenessTracker is configured (the feature is opt-in) or
+// duties is nil.
+func (c *MultiBeaconClient) skipOfflineProposerDuties(duties *ProposerDutiesResponse) *ProposerDutiesResponse {
+	livenessTracker := c.livenessTracker
+	if livenessTracker == nil || duties == nil {
+		return duties
 	}
 
-	var lastErrPublishResp publishResp
-	for i := 0; i < len(clients); i++ {
-		res := <-resChans
-		log = log.WithField("beacon", clients[res.index].GetURI())
-		if res.err != nil {
-			log.WithField("statusCode", res.code).WithError(res.err).Warn("failed to publish block")
-			lastErrPublishResp = res
-			continue
-		} else if res.code == 202 {
-			// Should the block fail full validation, a separate success response code (202) is used to indicate that the block was successfully broadcast but failed integration.
-			// https://ethereum.github.io/beacon-APIs/?urls.primaryName=dev#/Beacon/publishBlock
-			log.WithField("statusCode", res.code).WithError(res.err).Error("block failed validation but was still broadcast")
-			lastErrPublishResp = res
+	kept := make([]ProposerDuty, 0, len(duties.Data))
+	for _, duty := range duties.Data {
+		validatorIndex, err := strconv.ParseUint(duty.ValidatorIndex, 10, 64)
+		if err != nil {
+			c.log.WithField("validatorIndex", duty.ValidatorIndex).WithError(err).Warn("failed to parse proposer duty validator index, skipping liveness check for it")
+			kept = append(kept, duty)
 			continue
 		}
 
-		c.bestBeaconIndex.Store(int64(res.index))
+		if livenessTracker.ShouldSkip(validatorIndex) {
+			c.log.WithField("validatorIndex", validatorIndex).Info("skipping proposer duty for an offline validator")
+			continue
+		}
 
-		log.WithField("statusCode", res.code).Info("published block")
-		return res.code, nil
+		kept = append(kept, duty)
 	}
 
-	log.Error("failed to publish block on any CL node")
-	return lastErrPublishResp.code, fmt.Errorf("last error: %w", lastErrPublishResp.err)
+	out := *duties
+	out.Data = kept
+	return &out
+}
+
+type publishResp struct {
+	index int
+	code  int
+	err   error
 }
 
 // GetGenesis returns the genesis info - https://ethereum.github.io/beacon-APIs/#/Beacon/getGenesis
 func (c *MultiBeaconClient) GetGenesis() (genesisInfo *GetGenesisResponse, err error) {
-	clients := c.beaconInstancesByLastResponse()
-	for i, client := range clients {
+	clients := c.instancesFor(RoleReads)
+	for _, client := range clients {
 		log := c.log.WithField("uri", client.GetURI())
-		if genesisInfo, err = client.GetGenesis(); err != nil {
+		start := time.Now()
+		genesisInfo, err = client.GetGenesis()
+		c.recordResult(client.GetURI(), RoleReads, time.Since(start), err)
+		if err != nil {
 			log.WithError(err).Warn("failed to get genesis info")
 			continue
 		}
 
-		c.bestBeaconIndex.Store(int64(i))
-
 		return genesisInfo, nil
 	}
 
@@ -290,10 +413,13 @@ func (c *MultiBeaconClient) GetGenesis() (genesisInfo *GetGenesisResponse, err e
 
 // GetSpec - https://ethereum.github.io/beacon-APIs/#/Config/getSpec
 func (c *MultiBeaconClient) GetSpec() (spec *GetSpecResponse, err error) {
-	clients := c.beaconInstancesByLastResponse()
+	clients := c.instancesFor(RoleReads)
 	for _, client := range clients {
 		log := c.log.WithField("uri", client.GetURI())
-		if spec, err = client.GetSpec(); err != nil {
+		start := time.Now()
+		spec, err = client.GetSpec()
+		c.recordResult(client.GetURI(), RoleReads, time.Since(start), err)
+		if err != nil {
 			log.WithError(err).Warn("failed to get spec")
 			continue
 		}
@@ -307,16 +433,17 @@ func (c *MultiBeaconClient) GetSpec() (spec *GetSpecResponse, err error) {
 
 // GetForkSchedule - https://ethereum.github.io/beacon-APIs/#/Config/getForkSchedule
 func (c *MultiBeaconClient) GetForkSchedule() (spec *GetForkScheduleResponse, err error) {
-	clients := c.beaconInstancesByLastResponse()
-	for i, client := range clients {
+	clients := c.instancesFor(RoleReads)
+	for _, client := range clients {
 		log := c.log.WithField("uri", client.GetURI())
-		if spec, err = client.GetForkSchedule(); err != nil {
+		start := time.Now()
+		spec, err = client.GetForkSchedule()
+		c.recordResult(client.GetURI(), RoleReads, time.Since(start), err)
+		if err != nil {
 			log.WithError(err).Warn("failed to get fork schedule")
 			continue
 		}
 
-		c.bestBeaconIndex.Store(int64(i))
-
 		return spec, nil
 	}
 
@@ -326,14 +453,28 @@ func (c *MultiBeaconClient) GetForkSchedule() (spec *GetForkScheduleResponse, er
 
 // GetBlock returns a block - https://ethereum.github.io/beacon-APIs/#/Beacon/getBlockV2
 func (c *MultiBeaconClient) GetBlock(blockID string) (block *GetBlockResponse, err error) {
-	clients := c.beaconInstancesByLastResponse()
+	clients := c.instancesFor(RoleReads)
 	for _, client := range clients {
 		log := c.log.WithField("uri", client.GetURI())
-		if block, err = client.GetBlock(blockID); err != nil {
+		start := time.Now()
+		block, err = client.GetBlock(blockID, acceptHeader(client.PreferSSZ()))
+		if isUnsupportedMediaTypeErr(err) {
+			client.SetPreferSSZ(false)
+			block, err = client.GetBlock(blockID, contentTypeJSON)
+		}
+		c.recordResult(client.GetURI(), RoleReads, time.Since(start), err)
+		if err != nil {
 			log.WithField("blockID", blockID).WithError(err).Warn("failed to get block")
 			continue
 		}
 
+		// Cache the last successfully observed block so EngineFallback can derive a
+		// ForkchoiceStateV1 without an extra round-trip if every beacon node later fails to
+		// publish.
+		c.lastBlockMu.Lock()
+		c.lastBlock = block
+		c.lastBlockMu.Unlock()
+
 		return block, nil
 	}
 
@@ -342,43 +483,63 @@ func (c *MultiBeaconClient) GetBlock(blockID string) (block *GetBlockResponse, e
 }
 
 // GetRandao - 3500/eth/v1/beacon/states/<slot>/randao
-func (c *MultiBeaconClient) GetRandao(slot uint64) (randaoResp *GetRandaoResponse, err error) {
-	clients := c.beaconInstancesByLastResponse()
-	for i, client := range clients {
-		log := c.log.WithField("uri", client.GetURI())
-		if randaoResp, err = client.GetRandao(slot); err != nil {
-			log.WithField("slot", slot).WithError(err).Warn("failed to get randao")
-			continue
-		}
-
-		c.bestBeaconIndex.Store(int64(i))
+func (c *MultiBeaconClient) GetRandao(slot uint64) (*GetRandaoResponse, error) {
+	return c.cachedGetRandao(slot, func() (randaoResp *GetRandaoResponse, err error) {
+		clients := c.instancesFor(RoleDuties)
+		for _, client := range clients {
+			log := c.log.WithField("uri", client.GetURI())
+			start := time.Now()
+			randaoResp, err = client.GetRandao(slot, acceptHeader(client.PreferSSZ()))
+			if isUnsupportedMediaTypeErr(err) {
+				client.SetPreferSSZ(false)
+				randaoResp, err = client.GetRandao(slot, contentTypeJSON)
+			}
+			c.recordResult(client.GetURI(), RoleDuties, time.Since(start), err)
+			if err != nil {
+				log.WithField("slot", slot).WithError(err).Warn("failed to get randao")
+				continue
+			}
 
-		return randaoResp, nil
-	}
+			return randaoResp, nil
+		}
 
-	c.log.WithField("slot", slot).WithError(err).Warn("failed to get randao from any CL node")
-	return nil, err
+		c.log.WithField("slot", slot).WithError(err).Warn("failed to get randao from any CL node")
+		return nil, err
+	})
 }
 
 // GetWithdrawals - 3500/eth/v1/beacon/states/<slot>/withdrawals
 func (c *MultiBeaconClient) GetWithdrawals(slot uint64) (withdrawalsResp *GetWithdrawalsResponse, err error) {
-	clients := c.beaconInstancesByLastResponse()
-	for i, client := range clients {
+	if c.forkTracker.requireForkAtLeast(slot, ForkCapella) {
+		return nil, ErrWithdrawalsBeforeCapella
+	}
+
+	clients := c.instancesFor(RoleDuties)
+	for _, client := range clients {
 		log := c.log.WithField("uri", client.GetURI())
-		if withdrawalsResp, err = client.GetWithdrawals(slot); err != nil {
-			if strings.Contains(err.Error(), "Withdrawals not enabled before capella") {
+		start := time.Now()
+		withdrawalsResp, err = client.GetWithdrawals(slot, acceptHeader(client.PreferSSZ()))
+		if isUnsupportedMediaTypeErr(err) {
+			client.SetPreferSSZ(false)
+			withdrawalsResp, err = client.GetWithdrawals(slot, contentTypeJSON)
+		}
+		c.recordResult(client.GetURI(), RoleDuties, time.Since(start), err)
+		if err != nil {
+			if isWithdrawalsNotEnabledErr(err) {
 				break
 			}
 			log.WithField("slot", slot).WithError(err).Warn("failed to get withdrawals")
 			continue
 		}
 
-		c.bestBeaconIndex.Store(int64(i))
-
 		return withdrawalsResp, nil
 	}
 
-	if strings.Contains(err.Error(), "Withdrawals not enabled before capella") {
+	// Backstop for when the fork-tracker pre-flight check above couldn't short-circuit (it hasn't
+	// been started yet, or the fork schedule doesn't cover this slot): fall back to matching the
+	// CL's own error message so callers checking errors.Is(err, ErrWithdrawalsBeforeCapella) still
+	// get a normalized error instead of the raw CL response.
+	if isWithdrawalsNotEnabledErr(err) {
 		c.log.WithField("slot", slot).WithError(err).Debug("failed to get withdrawals as capella has not been reached")
 		return nil, ErrWithdrawalsBeforeCapella
 	}
@@ -386,3 +547,27 @@ func (c *MultiBeaconClient) GetWithdrawals(slot uint64) (withdrawalsResp *GetWit
 	c.log.WithField("slot", slot).WithError(err).Warn("failed to get withdrawals from any CL node")
 	return nil, err
 }
+
+// isWithdrawalsNotEnabledErr reports whether err is (or wraps) a CL's rejection of a
+// pre-Capella GetWithdrawals call.
+func isWithdrawalsNotEnabledErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "Withdrawals not enabled before capella")
+}
+
+// StartForkTracker populates the client's ForkTracker from GetGenesis/GetSpec/GetForkSchedule.
+// Call this once at startup before relying on fork-aware behavior such as GetWithdrawals'
+// pre-flight capella check.
+func (c *MultiBeaconClient) StartForkTracker() error {
+	return c.forkTracker.Start(c)
+}
+
+// CurrentForkDigest returns compute_fork_digest for the fork active at the given slot, per the
+// cached fork schedule. See ForkTracker.ForkDigest.
+func (c *MultiBeaconClient) CurrentForkDigest(slot uint64) ([4]byte, error) {
+	return c.forkTracker.ForkDigest(slot)
+}
+
+// CurrentFork returns the fork active at the given slot, per the cached fork schedule.
+func (c *MultiBeaconClient) CurrentFork(slot uint64) ForkName {
+	return c.forkTracker.CurrentFork(slot)
+}
@@ -0,0 +1,204 @@
+package beaconclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flashbots/mev-boost-relay/common"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultPublishQuorum is used when PublishBlock is called with quorum <= 0: return as soon as
+// a single beacon node acknowledges the block.
+const defaultPublishQuorum = 1
+
+// InstancePublishResult is one beacon instance's response to a PublishBlock call.
+type InstancePublishResult struct {
+	URI  string
+	Code int
+	Err  error
+}
+
+// PublishReport audits which CLs 202'd vs 200'd for a single PublishBlock call. Responses are
+// appended as they arrive, including ones that complete after PublishBlock has already returned
+// to the caller (the remaining broadcasts keep running in the background for redundancy), so
+// callers that want the full picture should read Responses() after the slot has passed.
+type PublishReport struct {
+	Code   int
+	Quorum int
+
+	mu        sync.Mutex
+	responses []InstancePublishResult
+}
+
+func (r *PublishReport) addResponse(res InstancePublishResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses = append(r.responses, res)
+}
+
+// Responses returns a snapshot of every instance result received so far.
+func (r *PublishReport) Responses() []InstancePublishResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]InstancePublishResult, len(r.responses))
+	copy(out, r.responses)
+	return out
+}
+
+// PublishBlock publishes the signed beacon block via
+// https://ethereum.github.io/beacon-APIs/#/ValidatorRequiredApi/publishBlock
+//
+// It returns as soon as `quorum` instances have acknowledged the block with a non-202 success
+// (quorum <= 0 defaults to 1, i.e. the first success wins), rather than waiting for every
+// broadcast to finish: this is the hottest path on the relay, and a single slow CL shouldn't
+// delay reporting success once enough other nodes accepted the block. Broadcasts that are still
+// in flight when quorum is reached (or ctx is done) keep running in the background for
+// redundancy; their results are still recorded into the returned PublishReport.
+func (c *MultiBeaconClient) PublishBlock(ctx context.Context, block *common.SignedBeaconBlock, quorum int) (*PublishReport, error) {
+	if quorum <= 0 {
+		quorum = defaultPublishQuorum
+	}
+
+	log := c.log.WithFields(logrus.Fields{
+		"slot":      block.Slot(),
+		"blockHash": block.BlockHash(),
+		"quorum":    quorum,
+	})
+
+	clients := c.instancesFor(RolePublish)
+	if quorum > len(clients) {
+		quorum = len(clients)
+	}
+
+	report := &PublishReport{Quorum: quorum}
+
+	// The chan will be cleaned up automatically once every goroutine has written to it, even if
+	// nobody is still reading by then.
+	resChans := make(chan publishResp, len(clients))
+
+	for i, client := range clients {
+		log := log.WithField("uri", client.GetURI())
+		log.Debug("publishing block")
+		go func(index int, client IBeaconInstance) {
+			start := time.Now()
+			contentType := contentTypeJSON
+			if client.PreferSSZ() {
+				contentType = contentTypeSSZ
+			}
+			code, err := client.PublishBlock(ctx, block, contentType)
+			if err == nil && isUnsupportedMediaType(code) {
+				client.SetPreferSSZ(false)
+				code, err = client.PublishBlock(ctx, block, contentTypeJSON)
+			}
+			c.recordResult(client.GetURI(), RolePublish, time.Since(start), err)
+			resChans <- publishResp{index: index, code: code, err: err}
+		}(i, client)
+	}
+
+	acks := 0
+	for i := 0; i < len(clients); i++ {
+		select {
+		case <-ctx.Done():
+			go c.drainPublishResponses(log, clients, resChans, len(clients)-i, report)
+			return report, ctx.Err()
+
+		case res := <-resChans:
+			uri := clients[res.index].GetURI()
+			recordPublishResponse(report, log, uri, res)
+
+			if res.err == nil && res.code != 202 {
+				acks++
+				if acks >= quorum {
+					go c.drainPublishResponses(log, clients, resChans, len(clients)-i-1, report)
+					report.Code = res.code
+					return report, nil
+				}
+			}
+		}
+	}
+
+	if acks > 0 {
+		log.WithField("acks", acks).Error("failed to reach publish quorum")
+		return report, ErrBeaconNodesUnavailable
+	}
+
+	log.Error("failed to publish block on any CL node")
+
+	if c.engineFallback != nil {
+		c.tryEngineFallback(log, block)
+	}
+
+	return report, ErrBeaconNodesUnavailable
+}
+
+// tryEngineFallback attempts to land the block's execution payload directly against the
+// configured EL when every beacon node failed to publish it. This is a best-effort last resort:
+// it cannot make the block canonical on the CL side, but it gives the EL a chance to import the
+// payload before the slot is lost entirely.
+func (c *MultiBeaconClient) tryEngineFallback(log *logrus.Entry, block *common.SignedBeaconBlock) {
+	fork := c.forkTracker.CurrentFork(block.Slot())
+
+	status, err := c.engineFallback.NewPayload(block, fork)
+	if err != nil {
+		log.WithError(err).Error("engine fallback: newPayload failed")
+		return
+	}
+	log.WithField("status", status).Warn("engine fallback: submitted payload directly to EL")
+
+	headHash := fmt.Sprintf("%s", block.BlockHash())
+
+	// The EL is expected to already know about the safe/finalized roots we send it; the block
+	// we're only now trying to land obviously isn't one of them yet. Use the last block we
+	// successfully observed via GetBlock - a block the EL has had a chance to import by normal
+	// means - as the safe/finalized root instead. Fall back to the new block's own hash only if
+	// we've never observed one, so forkchoiceUpdated still has something to send.
+	safeHash := headHash
+	c.lastBlockMu.Lock()
+	lastBlock := c.lastBlock
+	c.lastBlockMu.Unlock()
+	if lastBlock != nil {
+		safeHash = fmt.Sprintf("%s", lastBlock.BlockHash())
+	}
+
+	state := ForkchoiceStateV1{
+		HeadBlockHash:      headHash,
+		SafeBlockHash:      safeHash,
+		FinalizedBlockHash: safeHash,
+	}
+
+	fcuStatus, err := c.engineFallback.ForkchoiceUpdated(state, fork)
+	if err != nil {
+		log.WithError(err).Error("engine fallback: forkchoiceUpdated failed")
+		return
+	}
+	log.WithField("status", fcuStatus).Warn("engine fallback: forkchoiceUpdated sent directly to EL")
+}
+
+// drainPublishResponses reads the remaining in-flight PublishBlock responses after the caller has
+// already been returned to, so slower beacon nodes still get their redundant broadcast recorded.
+func (c *MultiBeaconClient) drainPublishResponses(log *logrus.Entry, clients []IBeaconInstance, resChans chan publishResp, remaining int, report *PublishReport) {
+	for i := 0; i < remaining; i++ {
+		res := <-resChans
+		recordPublishResponse(report, log, clients[res.index].GetURI(), res)
+	}
+}
+
+func recordPublishResponse(report *PublishReport, log *logrus.Entry, uri string, res publishResp) {
+	report.addResponse(InstancePublishResult{URI: uri, Code: res.code, Err: res.err})
+
+	l := log.WithField("uri", uri).WithField("statusCode", res.code)
+	switch {
+	case res.err != nil:
+		l.WithError(res.err).Warn("failed to publish block")
+	case res.code == 202:
+		// Should the block fail full validation, a separate success response code (202) is used to
+		// indicate that the block was successfully broadcast but failed integration.
+		// https://ethereum.github.io/beacon-APIs/?urls.primaryName=dev#/Beacon/publishBlock
+		l.Warn("block failed validation but was still broadcast")
+	default:
+		l.Info("published block")
+	}
+}
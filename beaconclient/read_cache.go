@@ -0,0 +1,163 @@
+package beaconclient
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/flashbots/go-boost-utils/types"
+	"golang.org/x/sync/singleflight"
+)
+
+// validatorsCacheTTL bounds how stale a cached GetStateValidators response is allowed to get, as
+// a safety net for the head-root invalidation in case the relay misses a head event.
+const validatorsCacheTTL = 32 * 12 * time.Second // ~32 slots
+
+// slotsPerEpochDefault is the mainnet default used for epoch-boundary invalidation; it's
+// intentionally independent of ForkTracker so cache invalidation keeps working even before the
+// tracker has been started.
+const slotsPerEpochDefault = 32
+
+// readCache coalesces and caches the hot, frequently-polled read endpoints
+// (GetProposerDuties, GetRandao, GetStateValidators) so that N concurrent callers during a
+// proposer-registration burst share one upstream request, and repeat callers within the same
+// epoch/slot/head don't hit the CL at all.
+type readCache struct {
+	sfGroup singleflight.Group
+
+	mu              sync.Mutex
+	currentHeadRoot string
+	dutiesByEpoch   map[uint64]*ProposerDutiesResponse
+	dutiesRoot      map[uint64]string // dependent_root observed for a cached epoch's duties
+	randaoBySlot    map[uint64]*GetRandaoResponse
+	validatorsCache map[string]validatorsCacheEntry // keyed by stateID
+}
+
+type validatorsCacheEntry struct {
+	headRoot  string
+	fetchedAt time.Time
+	data      map[types.PubkeyHex]ValidatorResponseEntry
+}
+
+func newReadCache() *readCache {
+	return &readCache{
+		dutiesByEpoch:   make(map[uint64]*ProposerDutiesResponse),
+		dutiesRoot:      make(map[uint64]string),
+		randaoBySlot:    make(map[uint64]*GetRandaoResponse),
+		validatorsCache: make(map[string]validatorsCacheEntry),
+	}
+}
+
+// onHeadEvent invalidates the randao cache for the slot the event belongs to (it's always
+// slot-specific, so it's always stale once that slot becomes head), invalidates the cached
+// proposer duties for the event's epoch only if the CL's current_duty_dependent_root for that
+// epoch has changed since we fetched them (a reorg across the dependent-root boundary), and
+// records the new head root so GetStateValidators can tell whether its cached entry is still
+// current. A plain epoch rollover doesn't need an explicit delete here: dutiesByEpoch is keyed
+// by epoch number, so duties for a new epoch are simply a cache miss.
+func (rc *readCache) onHeadEvent(event HeadEventData) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.currentHeadRoot = event.Block
+
+	delete(rc.randaoBySlot, event.Slot)
+
+	epoch := event.Slot / slotsPerEpochDefault
+	if cachedRoot, ok := rc.dutiesRoot[epoch]; ok && cachedRoot != event.CurrentDutyDependentRoot {
+		delete(rc.dutiesByEpoch, epoch)
+		delete(rc.dutiesRoot, epoch)
+	}
+
+	// Duties for past epochs are never looked up again; drop them so the maps don't grow
+	// unbounded over the life of a long-running process.
+	for cachedEpoch := range rc.dutiesByEpoch {
+		if cachedEpoch < epoch {
+			delete(rc.dutiesByEpoch, cachedEpoch)
+			delete(rc.dutiesRoot, cachedEpoch)
+		}
+	}
+}
+
+func (c *MultiBeaconClient) cachedGetProposerDuties(epoch uint64, fetch func() (*ProposerDutiesResponse, error)) (*ProposerDutiesResponse, error) {
+	rc := c.cache
+
+	rc.mu.Lock()
+	if cached, ok := rc.dutiesByEpoch[epoch]; ok {
+		rc.mu.Unlock()
+		return cached, nil
+	}
+	rc.mu.Unlock()
+
+	key := fmt.Sprintf("duties-%d", epoch)
+	v, err, _ := rc.sfGroup.Do(key, func() (interface{}, error) {
+		return fetch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	duties := v.(*ProposerDutiesResponse)
+
+	rc.mu.Lock()
+	rc.dutiesByEpoch[epoch] = duties
+	rc.dutiesRoot[epoch] = duties.DependentRoot
+	rc.mu.Unlock()
+
+	return duties, nil
+}
+
+func (c *MultiBeaconClient) cachedGetRandao(slot uint64, fetch func() (*GetRandaoResponse, error)) (*GetRandaoResponse, error) {
+	rc := c.cache
+
+	rc.mu.Lock()
+	if cached, ok := rc.randaoBySlot[slot]; ok {
+		rc.mu.Unlock()
+		return cached, nil
+	}
+	rc.mu.Unlock()
+
+	key := fmt.Sprintf("randao-%d", slot)
+	v, err, _ := rc.sfGroup.Do(key, func() (interface{}, error) {
+		return fetch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	randao := v.(*GetRandaoResponse)
+
+	rc.mu.Lock()
+	rc.randaoBySlot[slot] = randao
+	rc.mu.Unlock()
+
+	return randao, nil
+}
+
+func (c *MultiBeaconClient) cachedGetStateValidators(stateID string, fetch func() (map[types.PubkeyHex]ValidatorResponseEntry, error)) (map[types.PubkeyHex]ValidatorResponseEntry, error) {
+	rc := c.cache
+
+	rc.mu.Lock()
+	headRoot := rc.currentHeadRoot
+	if entry, ok := rc.validatorsCache[stateID]; ok && entry.headRoot == headRoot && time.Since(entry.fetchedAt) < validatorsCacheTTL {
+		rc.mu.Unlock()
+		return entry.data, nil
+	}
+	rc.mu.Unlock()
+
+	key := fmt.Sprintf("validators-%s-%s", stateID, headRoot)
+	v, err, _ := rc.sfGroup.Do(key, func() (interface{}, error) {
+		return fetch()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	validators := v.(map[types.PubkeyHex]ValidatorResponseEntry)
+
+	rc.mu.Lock()
+	rc.validatorsCache[stateID] = validatorsCacheEntry{headRoot: headRoot, fetchedAt: time.Now(), data: validators}
+	rc.mu.Unlock()
+
+	return validators, nil
+}
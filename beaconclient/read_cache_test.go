@@ -0,0 +1,63 @@
+package beaconclient
+
+import "testing"
+
+func TestCachedGetProposerDuties_SurvivesHeadEventsWithinEpoch(t *testing.T) {
+	c := &MultiBeaconClient{cache: newReadCache()}
+
+	fetches := 0
+	fetch := func() (*ProposerDutiesResponse, error) {
+		fetches++
+		return &ProposerDutiesResponse{DependentRoot: "0xroot1"}, nil
+	}
+
+	const epoch = 10
+	if _, err := c.cachedGetProposerDuties(epoch, fetch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetches != 1 {
+		t.Fatalf("expected 1 fetch after initial call, got %d", fetches)
+	}
+
+	// Head events for later slots within the same epoch, reporting the same dependent_root we
+	// already cached, must not evict the cached duties.
+	for _, slot := range []uint64{epoch*slotsPerEpochDefault + 1, epoch*slotsPerEpochDefault + 2} {
+		c.cache.onHeadEvent(HeadEventData{Slot: slot, Block: "0xhead", CurrentDutyDependentRoot: "0xroot1"})
+	}
+
+	if _, err := c.cachedGetProposerDuties(epoch, fetch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetches != 1 {
+		t.Fatalf("expected cache hit (still 1 fetch), got %d fetches", fetches)
+	}
+}
+
+func TestCachedGetProposerDuties_InvalidatedOnDependentRootChange(t *testing.T) {
+	c := &MultiBeaconClient{cache: newReadCache()}
+
+	fetches := 0
+	fetch := func() (*ProposerDutiesResponse, error) {
+		fetches++
+		return &ProposerDutiesResponse{DependentRoot: "0xroot1"}, nil
+	}
+
+	const epoch = 10
+	if _, err := c.cachedGetProposerDuties(epoch, fetch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetches != 1 {
+		t.Fatalf("expected 1 fetch after initial call, got %d", fetches)
+	}
+
+	// A reorg changes the epoch's current_duty_dependent_root: the cached duties are now stale
+	// and must be evicted so the next call refetches.
+	c.cache.onHeadEvent(HeadEventData{Slot: epoch * slotsPerEpochDefault, Block: "0xreorg", CurrentDutyDependentRoot: "0xroot2"})
+
+	if _, err := c.cachedGetProposerDuties(epoch, fetch); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetches != 2 {
+		t.Fatalf("expected eviction to trigger a refetch (2 fetches), got %d", fetches)
+	}
+}
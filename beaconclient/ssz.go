@@ -0,0 +1,48 @@
+package beaconclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Content types used for negotiating beacon-API request/response encoding,
+// per https://ethereum.github.io/beacon-APIs/#/
+const (
+	contentTypeJSON = "application/json"
+	contentTypeSSZ  = "application/octet-stream"
+)
+
+// acceptHeader returns the Accept header value to send for a given instance's
+// SSZ preference. SSZ is listed first so a CL that supports content
+// negotiation will prefer it, but JSON is always offered as a fallback.
+func acceptHeader(preferSSZ bool) string {
+	if preferSSZ {
+		return contentTypeSSZ + ", " + contentTypeJSON + ";q=0.9"
+	}
+	return contentTypeJSON
+}
+
+// isUnsupportedMediaType returns true if statusCode indicates the CL rejected our requested
+// encoding and the caller should retry the request with plain JSON.
+func isUnsupportedMediaType(statusCode int) bool {
+	return statusCode == http.StatusUnsupportedMediaType || statusCode == http.StatusNotAcceptable
+}
+
+// ErrUnsupportedContentType is returned (wrapped) by an IBeaconInstance's read methods when the
+// CL rejected the Accept header we sent with a 415 Unsupported Media Type or 406 Not Acceptable.
+// Callers should flip the instance to JSON via SetPreferSSZ(false) and retry once.
+type ErrUnsupportedContentType struct {
+	StatusCode int
+}
+
+func (e *ErrUnsupportedContentType) Error() string {
+	return fmt.Sprintf("beacon node rejected requested content type (status %d)", e.StatusCode)
+}
+
+// isUnsupportedMediaTypeErr reports whether err (or something it wraps) is an
+// ErrUnsupportedContentType, i.e. the caller should fall back to JSON and retry.
+func isUnsupportedMediaTypeErr(err error) bool {
+	var e *ErrUnsupportedContentType
+	return errors.As(err, &e)
+}
@@ -0,0 +1,17 @@
+package beaconclient
+
+import "time"
+
+const (
+	initialStreamBackoff = 1 * time.Second
+	maxStreamBackoff     = 30 * time.Second
+)
+
+// nextStreamBackoff doubles the backoff delay, capped at maxStreamBackoff.
+func nextStreamBackoff(current time.Duration) time.Duration {
+	next := current * 2
+	if next > maxStreamBackoff {
+		return maxStreamBackoff
+	}
+	return next
+}
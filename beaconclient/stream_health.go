@@ -0,0 +1,84 @@
+package beaconclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// streamingBeaconsGauge reports how many beacon instances currently have a live head-events
+// SSE connection, so operators can alert when a CL silently drops the stream.
+var streamingBeaconsGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "mevboost_relay_beacon_streams_active",
+	Help: "Number of beacon instances currently streaming head events",
+})
+
+// StreamHealth reports the liveness of a single beacon instance's SSE subscription(s),
+// so operators can alert when a CL silently drops the connection instead of noticing only
+// when duplicate/missing events cause downstream symptoms.
+type StreamHealth struct {
+	URI            string
+	LastEventTime  time.Time
+	ReconnectCount uint64
+}
+
+type streamHealthState struct {
+	mu             sync.Mutex
+	lastEventTime  time.Time
+	reconnectCount uint64
+}
+
+func (s *streamHealthState) recordEvent() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastEventTime = time.Now()
+}
+
+func (s *streamHealthState) recordReconnect() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reconnectCount++
+}
+
+func (s *streamHealthState) snapshot(uri string) StreamHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return StreamHealth{
+		URI:            uri,
+		LastEventTime:  s.lastEventTime,
+		ReconnectCount: s.reconnectCount,
+	}
+}
+
+func (c *MultiBeaconClient) streamHealthFor(uri string) *streamHealthState {
+	c.streamHealthMu.Lock()
+	defer c.streamHealthMu.Unlock()
+
+	s, ok := c.streamHealth[uri]
+	if !ok {
+		s = &streamHealthState{}
+		c.streamHealth[uri] = s
+	}
+	return s
+}
+
+// StreamStatus returns the last-event-age and reconnect count for every beacon instance's
+// event subscriptions, keyed by instance URI.
+func (c *MultiBeaconClient) StreamStatus() map[string]StreamHealth {
+	c.streamHealthMu.Lock()
+	uris := make([]string, 0, len(c.streamHealth))
+	states := make([]*streamHealthState, 0, len(c.streamHealth))
+	for uri, s := range c.streamHealth {
+		uris = append(uris, uri)
+		states = append(states, s)
+	}
+	c.streamHealthMu.Unlock()
+
+	out := make(map[string]StreamHealth, len(uris))
+	for i, uri := range uris {
+		out[uri] = states[i].snapshot(uri)
+	}
+	return out
+}
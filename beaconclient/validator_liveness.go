@@ -0,0 +1,127 @@
+package beaconclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+// GetValidatorLiveness returns, for each requested validator index, whether it was seen
+// proposing/attesting during epoch - https://ethereum.github.io/beacon-APIs/#/Validator/getLiveness
+func (c *MultiBeaconClient) GetValidatorLiveness(epoch uint64, indices []uint64) (liveness map[uint64]bool, err error) {
+	clients := c.instancesFor(RoleReads)
+	for _, client := range clients {
+		log := c.log.WithField("uri", client.GetURI())
+		start := time.Now()
+		liveness, err = client.GetValidatorLiveness(epoch, indices)
+		c.recordResult(client.GetURI(), RoleReads, time.Since(start), err)
+		if err != nil {
+			log.WithField("epoch", epoch).WithError(err).Warn("failed to get validator liveness")
+			continue
+		}
+
+		return liveness, nil
+	}
+
+	c.log.WithField("epoch", epoch).WithError(err).Warn("failed to get validator liveness from any CL node")
+	return nil, err
+}
+
+var offlineProposersGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "mevboost_relay_proposers_offline",
+	Help: "Number of upcoming registered proposers whose validators appear offline per the last liveness sample",
+})
+
+// LivenessPolicy decides, given a validator index that sampled offline, whether the relay should
+// still serve getHeader to it. Returning true skips serving the bid, protecting builders from
+// wasted bids on a proposer that's demonstrably not around to use it.
+type LivenessPolicy func(validatorIndex uint64) (skip bool)
+
+// LivenessTracker periodically samples GetValidatorLiveness for the previous epoch, over a
+// caller-supplied set of upcoming proposer indices, and tracks which currently appear offline.
+type LivenessTracker struct {
+	log    *logrus.Entry
+	client IMultiBeaconClient
+	policy LivenessPolicy
+
+	mu      sync.RWMutex
+	offline map[uint64]bool
+}
+
+// NewLivenessTracker returns a tracker sampling liveness via client. policy may be nil, in which
+// case ShouldSkip always returns false (sampling still runs, so the metric and IsOffline stay useful).
+func NewLivenessTracker(log *logrus.Entry, client IMultiBeaconClient, policy LivenessPolicy) *LivenessTracker {
+	return &LivenessTracker{
+		log:     log.WithField("component", "livenessTracker"),
+		client:  client,
+		policy:  policy,
+		offline: make(map[uint64]bool),
+	}
+}
+
+// Sample fetches liveness for the given proposer indices for currentEpoch-1 (the most recent
+// fully-attested epoch) and updates the offline set.
+func (t *LivenessTracker) Sample(currentEpoch uint64, proposerIndices []uint64) error {
+	if currentEpoch == 0 || len(proposerIndices) == 0 {
+		return nil
+	}
+
+	liveness, err := t.client.GetValidatorLiveness(currentEpoch-1, proposerIndices)
+	if err != nil {
+		return err
+	}
+
+	offline := make(map[uint64]bool, len(proposerIndices))
+	for _, index := range proposerIndices {
+		if isLive, ok := liveness[index]; ok && !isLive {
+			offline[index] = true
+		}
+	}
+
+	t.mu.Lock()
+	t.offline = offline
+	t.mu.Unlock()
+
+	offlineProposersGauge.Set(float64(len(offline)))
+	return nil
+}
+
+// IsOffline reports whether validatorIndex appeared offline in the most recent sample.
+func (t *LivenessTracker) IsOffline(validatorIndex uint64) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.offline[validatorIndex]
+}
+
+// ShouldSkip reports whether getHeader should be withheld from validatorIndex: it's offline per
+// the most recent sample, and the configured policy agrees to skip it.
+func (t *LivenessTracker) ShouldSkip(validatorIndex uint64) bool {
+	if !t.IsOffline(validatorIndex) {
+		return false
+	}
+	if t.policy == nil {
+		return false
+	}
+	return t.policy(validatorIndex)
+}
+
+// Run samples liveness on every tick until ctx-like stop channel closes. getProposerIndices
+// supplies the current set of upcoming registered proposers to sample each tick.
+func (t *LivenessTracker) Run(stopC <-chan struct{}, interval time.Duration, currentEpochFunc func() uint64, getProposerIndices func() []uint64) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopC:
+			return
+		case <-ticker.C:
+			if err := t.Sample(currentEpochFunc(), getProposerIndices()); err != nil {
+				t.log.WithError(err).Warn("failed to sample validator liveness")
+			}
+		}
+	}
+}
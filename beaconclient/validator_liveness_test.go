@@ -0,0 +1,79 @@
+package beaconclient
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestSkipOfflineProposerDuties_DropsOfflineValidatorsPolicyAgreesToSkip(t *testing.T) {
+	lt := &LivenessTracker{
+		offline: map[uint64]bool{7: true},
+		policy:  func(uint64) bool { return true },
+	}
+	c := &MultiBeaconClient{log: logrus.NewEntry(logrus.New()), livenessTracker: lt}
+
+	duties := &ProposerDutiesResponse{
+		DependentRoot: "0xroot",
+		Data: []ProposerDuty{
+			{ValidatorIndex: "7"},
+			{ValidatorIndex: "8"},
+		},
+	}
+
+	got := c.skipOfflineProposerDuties(duties)
+	if len(got.Data) != 1 || got.Data[0].ValidatorIndex != "8" {
+		t.Fatalf("expected only validator 8's duty to remain, got %+v", got.Data)
+	}
+}
+
+func TestSkipOfflineProposerDuties_KeepsOfflineValidatorWhenPolicyDisagrees(t *testing.T) {
+	lt := &LivenessTracker{
+		offline: map[uint64]bool{7: true},
+		policy:  func(uint64) bool { return false },
+	}
+	c := &MultiBeaconClient{log: logrus.NewEntry(logrus.New()), livenessTracker: lt}
+
+	duties := &ProposerDutiesResponse{Data: []ProposerDuty{{ValidatorIndex: "7"}}}
+
+	got := c.skipOfflineProposerDuties(duties)
+	if len(got.Data) != 1 {
+		t.Fatalf("expected the duty to be kept since the policy declines to skip it, got %+v", got.Data)
+	}
+}
+
+func TestSkipOfflineProposerDuties_NoTrackerConfiguredReturnsUnchanged(t *testing.T) {
+	c := &MultiBeaconClient{log: logrus.NewEntry(logrus.New())}
+
+	duties := &ProposerDutiesResponse{Data: []ProposerDuty{{ValidatorIndex: "7"}}}
+	if got := c.skipOfflineProposerDuties(duties); got != duties {
+		t.Fatalf("expected duties to be returned unchanged when no LivenessTracker is configured")
+	}
+}
+
+func TestLivenessTracker_IsOfflineAndShouldSkip(t *testing.T) {
+	lt := NewLivenessTracker(logrus.NewEntry(logrus.New()), nil, func(uint64) bool { return true })
+	lt.offline = map[uint64]bool{42: true}
+
+	if !lt.IsOffline(42) {
+		t.Fatal("expected validator 42 to be reported offline")
+	}
+	if lt.IsOffline(43) {
+		t.Fatal("expected validator 43 to be reported online")
+	}
+	if !lt.ShouldSkip(42) {
+		t.Fatal("expected ShouldSkip to agree for an offline validator when policy allows it")
+	}
+	if lt.ShouldSkip(43) {
+		t.Fatal("expected ShouldSkip to be false for an online validator")
+	}
+}
+
+func TestLivenessTracker_ShouldSkipWithNilPolicyNeverSkips(t *testing.T) {
+	lt := NewLivenessTracker(logrus.NewEntry(logrus.New()), nil, nil)
+	lt.offline = map[uint64]bool{42: true}
+
+	if lt.ShouldSkip(42) {
+		t.Fatal("expected ShouldSkip to be false when no policy is configured, even for an offline validator")
+	}
+}